@@ -0,0 +1,110 @@
+// Package client is a typed Go client for the papaya HTTP API described in
+// api/openapi.json. It is maintained by hand, with one method per spec
+// operationId; tests in api check that every operation has a method and run
+// the client against the real handlers, so drift shows up as a failure.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Health mirrors the Health schema.
+type Health struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// LegacyHealth mirrors the LegacyHealth schema.
+type LegacyHealth struct {
+	Status string `json:"Status"`
+}
+
+// CheckResult mirrors the CheckResult schema.
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Client calls a papaya server at BaseURL. A nil HTTPClient means
+// http.DefaultClient.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a Client for baseURL using http.DefaultClient.
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Healthz calls GET /healthz.
+func (c *Client) Healthz(ctx context.Context) (*Health, error) {
+	var h Health
+	if err := c.get(ctx, "/healthz", &h, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// Healthcheck calls the deprecated GET /healthcheck; prefer Healthz.
+func (c *Client) Healthcheck(ctx context.Context) (*LegacyHealth, error) {
+	var h LegacyHealth
+	if err := c.get(ctx, "/healthcheck", &h, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// OpenAPI calls GET /openapi.json and returns the raw document.
+func (c *Client) OpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var doc json.RawMessage
+	if err := c.get(ctx, "/openapi.json", &doc, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Readyz calls GET /readyz. A 503 response is not an error: the returned
+// Health lists the failing checks.
+func (c *Client) Readyz(ctx context.Context) (*Health, error) {
+	var h Health
+	if err := c.get(ctx, "/readyz", &h, http.StatusOK, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}, codes ...int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	ok := false
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("GET %s: unexpected status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
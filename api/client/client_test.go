@@ -0,0 +1,116 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/guanke/papaya/api"
+	"github.com/guanke/papaya/api/client"
+)
+
+func newServer(t *testing.T, checks map[string]api.Check) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(api.NewHandler(checks))
+	t.Cleanup(srv.Close)
+	return client.New(srv.URL)
+}
+
+func TestHealthz(t *testing.T) {
+	c := newServer(t, nil)
+
+	h, err := c.Healthz(context.Background())
+	if err != nil {
+		t.Fatalf("Healthz() = %v", err)
+	}
+	if h.Status != "ok" {
+		t.Errorf("Status = %q, want ok", h.Status)
+	}
+}
+
+func TestReadyzOK(t *testing.T) {
+	c := newServer(t, map[string]api.Check{
+		"db": func(context.Context) error { return nil },
+	})
+
+	h, err := c.Readyz(context.Background())
+	if err != nil {
+		t.Fatalf("Readyz() = %v", err)
+	}
+	if h.Status != "ok" {
+		t.Errorf("Status = %q, want ok", h.Status)
+	}
+	if got := h.Checks["db"]; got.Status != "ok" {
+		t.Errorf("db = %+v, want ok", got)
+	}
+}
+
+func TestReadyzFailingCheck(t *testing.T) {
+	c := newServer(t, map[string]api.Check{
+		"db": func(context.Context) error { return nil },
+		"r2": func(context.Context) error { return errors.New("bucket unreachable") },
+	})
+
+	h, err := c.Readyz(context.Background())
+	if err != nil {
+		t.Fatalf("Readyz() = %v, want the 503 body decoded", err)
+	}
+	if h.Status != "unavailable" {
+		t.Errorf("Status = %q, want unavailable", h.Status)
+	}
+	if got := h.Checks["db"]; got.Status != "ok" {
+		t.Errorf("db = %+v, want ok", got)
+	}
+	if got := h.Checks["r2"]; got.Status != "fail" || got.Error != "bucket unreachable" {
+		t.Errorf("r2 = %+v, want fail: bucket unreachable", got)
+	}
+}
+
+func TestUnexpectedStatus(t *testing.T) {
+	srv := httptest.NewServer(api.NewHandler(nil))
+	t.Cleanup(srv.Close)
+
+	c := client.New(srv.URL + "/missing")
+	if _, err := c.Healthz(context.Background()); err == nil {
+		t.Fatal("Healthz() against a 404 = nil error, want error")
+	}
+}
+
+func TestZeroValueHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(api.NewHandler(nil))
+	t.Cleanup(srv.Close)
+
+	c := &client.Client{BaseURL: srv.URL}
+	if _, err := c.Healthz(context.Background()); err != nil {
+		t.Fatalf("Healthz() with nil HTTPClient = %v", err)
+	}
+}
+
+func TestHealthcheck(t *testing.T) {
+	c := newServer(t, nil)
+
+	h, err := c.Healthcheck(context.Background())
+	if err != nil {
+		t.Fatalf("Healthcheck() = %v", err)
+	}
+	if h.Status != "OK" {
+		t.Errorf("Status = %q, want OK", h.Status)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	c := newServer(t, nil)
+
+	doc, err := c.OpenAPI(context.Background())
+	if err != nil {
+		t.Fatalf("OpenAPI() = %v", err)
+	}
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(doc, &spec); err != nil || spec.OpenAPI == "" {
+		t.Errorf("OpenAPI() returned %q, want an OpenAPI document", doc)
+	}
+}
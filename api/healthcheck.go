@@ -1,13 +1,111 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"sort"
+	"time"
 )
 
-func Healthcheck(w http.ResponseWriter, r *http.Request) {
-	w.Header().Add("Content-Type", "application/json") // todo: move to middleware
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
 
-	w.Write([]byte(`{"Status": "OK"}`))
+const checkTimeout = 5 * time.Second
+
+// CheckResult is the outcome of one named Check.
+type CheckResult struct {
+	Name   string `json:"-"`
+	Status string `json:"status"` // "ok" or "fail"
+	Error  string `json:"error,omitempty"`
+}
+
+// OK reports whether the check passed.
+func (r CheckResult) OK() bool { return r.Status == "ok" }
+
+type healthResponse struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Healthcheck is the original liveness endpoint. Its payload is kept as-is
+// for probes that match on the body; new probes should use Healthz.
+func Healthcheck(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"Status": "OK"}`))
+}
+
+// Healthz reports liveness: the process is up and serving HTTP.
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// RunChecks runs every check concurrently and returns the results sorted by
+// name. Checks that have not finished when ctx is done are reported as
+// failed.
+func RunChecks(ctx context.Context, checks map[string]Check) []CheckResult {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type done struct {
+		i   int
+		err error
+	}
+	// Buffered so checks that finish after the deadline don't block.
+	donec := make(chan done, len(names))
+	for i, name := range names {
+		go func(i int, check Check) {
+			donec <- done{i, check(ctx)}
+		}(i, checks[name])
+	}
+
+	results := make([]CheckResult, len(names))
+	for i, name := range names {
+		results[i] = CheckResult{Name: name, Status: "fail", Error: "timed out"}
+	}
+	for n := 0; n < len(names); n++ {
+		select {
+		case d := <-donec:
+			if d.err != nil {
+				results[d.i].Error = d.err.Error()
+			} else {
+				results[d.i].Status, results[d.i].Error = "ok", ""
+			}
+		case <-ctx.Done():
+			return results
+		}
+	}
+	return results
+}
+
+// Readyz returns a handler that runs every check and reports each
+// dependency's status. It responds 503 if any check fails or has not
+// finished within checkTimeout.
+func Readyz(checks map[string]Check) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+
+		results := RunChecks(ctx, checks)
+		resp := healthResponse{Status: "ok", Checks: make(map[string]CheckResult, len(results))}
+		code := http.StatusOK
+		for _, res := range results {
+			resp.Checks[res.Name] = res
+			if !res.OK() {
+				resp.Status = "unavailable"
+				code = http.StatusServiceUnavailable
+			}
+		}
+		writeJSON(w, code, resp)
+	}
+}
 
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
 }
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthcheckLegacyPayload(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Healthcheck(rec, httptest.NewRequest(http.MethodGet, "/healthcheck", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+	if got, want := rec.Body.String(), `{"Status": "OK"}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	h := Readyz(map[string]Check{
+		"good": func(context.Context) error { return nil },
+		"bad":  func(context.Context) error { return errors.New("boom") },
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+	var resp healthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "unavailable" {
+		t.Errorf("status = %q, want unavailable", resp.Status)
+	}
+	if got := resp.Checks["good"]; got.Status != "ok" {
+		t.Errorf("good = %+v, want ok", got)
+	}
+	if got := resp.Checks["bad"]; got.Status != "fail" || got.Error != "boom" {
+		t.Errorf("bad = %+v, want fail: boom", got)
+	}
+}
+
+func TestReadyzStuckCheckTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	h := Readyz(map[string]Check{
+		"stuck": func(context.Context) error { <-release; return nil }, // ignores ctx
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+
+	finished := make(chan struct{})
+	go func() {
+		h(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil).WithContext(ctx))
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("Readyz did not return after its context expired")
+	}
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+	var resp healthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Checks["stuck"]; got.Status != "fail" {
+		t.Errorf("stuck = %+v, want fail", got)
+	}
+}
+
+func TestRunChecksSortedByName(t *testing.T) {
+	results := RunChecks(context.Background(), map[string]Check{
+		"c": func(context.Context) error { return nil },
+		"a": func(context.Context) error { return errors.New("down") },
+		"b": func(context.Context) error { return nil },
+	})
+
+	want := []CheckResult{
+		{Name: "a", Status: "fail", Error: "down"},
+		{Name: "b", Status: "ok"},
+		{Name: "c", Status: "ok"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, results[i], want[i])
+		}
+	}
+}
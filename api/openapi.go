@@ -0,0 +1,15 @@
+package api
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed openapi.json
+var openapiSpec []byte
+
+// OpenAPI serves the OpenAPI document describing this HTTP API.
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openapiSpec)
+}
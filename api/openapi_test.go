@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/guanke/papaya/api/client"
+)
+
+func TestOpenAPIListsEveryRoute(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json = %d, want 200", rec.Code)
+	}
+
+	var spec struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec does not parse: %v", err)
+	}
+	if spec.OpenAPI == "" {
+		t.Error("spec has no openapi version")
+	}
+
+	mounted := make(map[string]bool)
+	for _, r := range routes(nil) {
+		mounted[r.pattern] = true
+		if _, ok := spec.Paths[r.pattern]; !ok {
+			t.Errorf("route %s is mounted but missing from the spec", r.pattern)
+		}
+	}
+	for path := range spec.Paths {
+		if !mounted[path] {
+			t.Errorf("spec path %s is not mounted", path)
+		}
+	}
+}
+
+func TestClientCoversEveryOperation(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(openapiSpec, &spec); err != nil {
+		t.Fatalf("spec does not parse: %v", err)
+	}
+
+	methods := make(map[string]bool)
+	typ := reflect.TypeOf(&client.Client{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		methods[strings.ToLower(typ.Method(i).Name)] = true
+	}
+
+	for path, ops := range spec.Paths {
+		for verb, op := range ops {
+			if op.OperationID == "" {
+				t.Errorf("%s %s has no operationId", verb, path)
+				continue
+			}
+			if !methods[strings.ToLower(op.OperationID)] {
+				t.Errorf("client has no method for operation %s (%s %s)", op.OperationID, verb, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+)
+
+type route struct {
+	pattern string
+	handler http.Handler
+}
+
+func routes(checks map[string]Check) []route {
+	return []route{
+		{"/healthz", http.HandlerFunc(Healthz)},
+		{"/healthcheck", http.HandlerFunc(Healthcheck)},
+		{"/readyz", Readyz(checks)},
+		{"/openapi.json", http.HandlerFunc(OpenAPI)},
+	}
+}
+
+// NewHandler returns a mux serving every route of the HTTP API, with checks
+// backing /readyz.
+func NewHandler(checks map[string]Check) http.Handler {
+	mux := http.NewServeMux()
+	for _, r := range routes(checks) {
+		mux.Handle(r.pattern, r.handler)
+	}
+	return mux
+}
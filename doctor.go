@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/guanke/papaya/api"
+	"github.com/guanke/papaya/internal/config"
+)
+
+const doctorTimeout = 10 * time.Second
+
+// dependencyChecks returns the checks shared by /readyz, the startup check
+// and doctor.
+func dependencyChecks(cfg *config.Config) map[string]api.Check {
+	return map[string]api.Check{}
+}
+
+// doctor loads the config itself, so that each validation problem shows up
+// as its own row instead of stopping the command.
+func doctor(configPath string, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+
+	cfg, err := loadConfig(configPath)
+	return runDoctor(os.Stdout, cfg, err, dependencyChecks)
+}
+
+// runDoctor writes a pass/fail table to w for the outcome of loading the
+// config (cfg and loadErr) and, if it loaded, for the checks it configures.
+// It returns an error if any row failed.
+func runDoctor(w io.Writer, cfg *config.Config, loadErr error, checks func(*config.Config) map[string]api.Check) error {
+	var results []api.CheckResult
+	var deps map[string]api.Check
+	var verr *config.ValidationError
+	switch {
+	case errors.As(loadErr, &verr):
+		for _, p := range verr.Problems {
+			results = append(results, api.CheckResult{Name: "config", Status: "fail", Error: p})
+		}
+	case loadErr != nil:
+		results = append(results, api.CheckResult{Name: "config", Status: "fail", Error: loadErr.Error()})
+	default:
+		results = append(results, api.CheckResult{Name: "config", Status: "ok"})
+
+		ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+		defer cancel()
+		deps = checks(cfg)
+		results = append(results, api.RunChecks(ctx, deps)...)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tRESULT\tDETAIL")
+	failed := 0
+	for _, res := range results {
+		if !res.OK() {
+			failed++
+			// Multi-line errors (e.g. from the YAML decoder) continue on
+			// rows of their own so the table stays aligned.
+			lines := strings.Split(strings.TrimSpace(res.Error), "\n")
+			fmt.Fprintf(tw, "%s\tFAIL\t%s\n", res.Name, lines[0])
+			for _, line := range lines[1:] {
+				fmt.Fprintf(tw, "\t\t%s\n", strings.TrimSpace(line))
+			}
+			continue
+		}
+		fmt.Fprintf(tw, "%s\tPASS\t\n", res.Name)
+	}
+	switch {
+	case loadErr != nil:
+		fmt.Fprintln(tw, "dependencies\tSKIP\tconfig is invalid")
+	case len(deps) == 0:
+		fmt.Fprintln(tw, "dependencies\tSKIP\tno checks registered")
+	}
+	tw.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+	return nil
+}
+
+// startupCheck runs the dependency checks before serve goes live.
+func startupCheck(cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	var failed []error
+	for _, res := range api.RunChecks(ctx, dependencyChecks(cfg)) {
+		if !res.OK() {
+			failed = append(failed, fmt.Errorf("%s: %s", res.Name, res.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("startup check failed (run papaya doctor for details): %w", errors.Join(failed...))
+	}
+	return nil
+}
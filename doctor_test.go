@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/guanke/papaya/api"
+	"github.com/guanke/papaya/internal/config"
+)
+
+// rows returns the table rendered by runDoctor as whitespace-separated fields,
+// without the header.
+func rows(out string) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n")[1:] {
+		rows = append(rows, strings.Fields(line))
+	}
+	return rows
+}
+
+func noChecks(*config.Config) map[string]api.Check { return nil }
+
+func TestRunDoctorValidationError(t *testing.T) {
+	var out bytes.Buffer
+	loadErr := &config.ValidationError{Problems: []string{
+		`addr: "foo" is not host:port`,
+		`log_level: "x" is not one of debug, info, warn, error`,
+	}}
+
+	err := runDoctor(&out, nil, loadErr, noChecks)
+	if err == nil || err.Error() != "2 of 2 checks failed" {
+		t.Errorf("runDoctor() = %v, want 2 of 2 checks failed", err)
+	}
+
+	got := rows(out.String())
+	want := [][]string{
+		{"config", "FAIL", "addr:"},
+		{"config", "FAIL", "log_level:"},
+		{"dependencies", "SKIP", "config"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got rows %q, want %d rows", got, len(want))
+	}
+	for i := range want {
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("row %d = %q, want prefix %q", i, got[i], want[i])
+				break
+			}
+		}
+	}
+}
+
+func TestRunDoctorFailingCheck(t *testing.T) {
+	var out bytes.Buffer
+	checks := func(*config.Config) map[string]api.Check {
+		return map[string]api.Check{
+			"db":       func(context.Context) error { return nil },
+			"telegram": func(context.Context) error { return errors.New("unauthorized") },
+		}
+	}
+
+	err := runDoctor(&out, &config.Config{}, nil, checks)
+	if err == nil || err.Error() != "1 of 3 checks failed" {
+		t.Errorf("runDoctor() = %v, want 1 of 3 checks failed", err)
+	}
+
+	got := rows(out.String())
+	want := [][]string{
+		{"config", "PASS"},
+		{"db", "PASS"},
+		{"telegram", "FAIL", "unauthorized"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got rows %q, want %d rows", got, len(want))
+	}
+	for i := range want {
+		if strings.Join(got[i], " ") != strings.Join(want[i], " ") {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRunDoctorMultilineError(t *testing.T) {
+	var out bytes.Buffer
+	loadErr := errors.New("parse config papaya.yaml: yaml: unmarshal errors:\n  line 1: field adr not found\n  line 2: field log_levle not found")
+
+	if err := runDoctor(&out, nil, loadErr, noChecks); err == nil {
+		t.Fatal("runDoctor() = nil, want error")
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	want := []string{
+		"CHECK         RESULT  DETAIL",
+		"config        FAIL    parse config papaya.yaml: yaml: unmarshal errors:",
+		"                      line 1: field adr not found",
+		"                      line 2: field log_levle not found",
+		"dependencies  SKIP    config is invalid",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines:\n%s\nwant %d", len(lines), out.String(), len(want))
+	}
+	for i := range want {
+		if strings.TrimRight(lines[i], " ") != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestRunDoctorNoChecksRegistered(t *testing.T) {
+	var out bytes.Buffer
+
+	if err := runDoctor(&out, &config.Config{}, nil, noChecks); err != nil {
+		t.Fatalf("runDoctor() = %v, want nil", err)
+	}
+
+	got := rows(out.String())
+	want := []string{"config PASS", "dependencies SKIP no checks registered"}
+	if len(got) != len(want) {
+		t.Fatalf("got rows %q, want %q", got, want)
+	}
+	for i := range want {
+		if strings.Join(got[i], " ") != want[i] {
+			t.Errorf("row %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
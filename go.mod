@@ -1,3 +1,5 @@
 module github.com/guanke/papaya
 
-go 1.16
+go 1.21
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds all settings for papaya.
+type Config struct {
+	Addr string `yaml:"addr"`
+	// ShutdownTimeout bounds how long in-flight requests are drained on
+	// SIGINT/SIGTERM.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	LogLevel  string `yaml:"log_level"`
+	LogFormat string `yaml:"log_format"`
+}
+
+// Profiles select the defaults used before the config file and
+// environment are applied.
+const (
+	ProfileDev  = "dev"
+	ProfileProd = "prod"
+)
+
+func defaults(profile string) Config {
+	if profile == ProfileDev {
+		return Config{
+			Addr:            "localhost:3000",
+			ShutdownTimeout: 10 * time.Second,
+			LogLevel:        "debug",
+			LogFormat:       "text",
+		}
+	}
+	return Config{
+		Addr:            ":3000",
+		ShutdownTimeout: 10 * time.Second,
+		LogLevel:        "info",
+		LogFormat:       "json",
+	}
+}
+
+// Load picks defaults for the PROFILE environment variable (prod when
+// unset), reads a local .env file in the dev profile, reads the YAML config
+// file at path (if non-empty), applies environment variable overrides on top
+// of it and validates the result.
+func Load(path string) (*Config, error) {
+	profile := os.Getenv("PROFILE")
+	switch profile {
+	case "":
+		profile = ProfileProd
+	case ProfileDev, ProfileProd:
+	default:
+		return nil, fmt.Errorf("unknown PROFILE %q (want %s or %s)", profile, ProfileDev, ProfileProd)
+	}
+
+	if profile == ProfileDev {
+		if err := loadDotenv(".env"); err != nil {
+			return nil, fmt.Errorf("load .env: %w", err)
+		}
+	}
+	cfg := defaults(profile)
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true) // report misspelled keys instead of ignoring them
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse config %s: %w", path, err)
+		}
+	}
+
+	// Environment values that fail to parse are reported together with
+	// the Validate problems.
+	var problems []string
+	if v := os.Getenv("PAPAYA_ADDR"); v != "" {
+		cfg.Addr = v
+	}
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			problems = append(problems, fmt.Sprintf("SHUTDOWN_TIMEOUT: %q is not a duration", v))
+		} else {
+			cfg.ShutdownTimeout = d
+		}
+	}
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		cfg.LogLevel = v
+	}
+	if v := os.Getenv("LOG_FORMAT"); v != "" {
+		cfg.LogFormat = v
+	}
+
+	if err := cfg.Validate(); err != nil {
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			return nil, err
+		}
+		problems = append(problems, verr.Problems...)
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadReadsDotenvOnlyInDev(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	if err := os.WriteFile(".env", []byte("PAPAYA_ADDR=:4000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PAPAYA_ADDR", "")
+	os.Unsetenv("PAPAYA_ADDR")
+
+	t.Setenv("PROFILE", ProfileProd)
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if cfg.Addr != ":3000" {
+		t.Errorf("prod Addr = %q, want :3000 (.env must be ignored)", cfg.Addr)
+	}
+
+	t.Setenv("PROFILE", ProfileDev)
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if cfg.Addr != ":4000" {
+		t.Errorf("dev Addr = %q, want :4000 from .env", cfg.Addr)
+	}
+}
+
+func TestLoadAggregatesEnvParseErrors(t *testing.T) {
+	t.Setenv("PROFILE", ProfileProd)
+	t.Setenv("SHUTDOWN_TIMEOUT", "bad")
+	t.Setenv("LOG_LEVEL", "x")
+	t.Setenv("PAPAYA_ADDR", "foo")
+
+	_, err := Load("")
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Load() = %v, want *ValidationError", err)
+	}
+
+	want := []string{"SHUTDOWN_TIMEOUT:", "addr:", "log_level:"}
+	if len(verr.Problems) != len(want) {
+		t.Fatalf("got %d problems %q, want %d", len(verr.Problems), verr.Problems, len(want))
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(verr.Problems[i], prefix) {
+			t.Errorf("problem %d = %q, want prefix %q", i, verr.Problems[i], prefix)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	t.Setenv("PROFILE", ProfileProd)
+	path := filepath.Join(t.TempDir(), "papaya.yaml")
+	if err := os.WriteFile(path, []byte("adr: \":1\"\nlog_levle: debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("Load() = nil, want error for misspelled keys")
+	}
+	for _, key := range []string{"adr", "log_levle"} {
+		if !strings.Contains(err.Error(), "field "+key+" not found") {
+			t.Errorf("Load() = %q, want it to name unknown key %s", err, key)
+		}
+	}
+}
+
+func TestLoadEmptyFile(t *testing.T) {
+	t.Setenv("PROFILE", ProfileProd)
+	path := filepath.Join(t.TempDir(), "papaya.yaml")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err != nil {
+		t.Fatalf("Load() = %v, want defaults for an empty file", err)
+	}
+}
+
+func TestLoadFileAndEnv(t *testing.T) {
+	const file = `addr: "127.0.0.1:4000"
+shutdown_timeout: 30s
+log_level: warn
+log_format: text
+`
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Config
+	}{
+		{
+			name: "file only",
+			want: Config{Addr: "127.0.0.1:4000", ShutdownTimeout: 30 * time.Second, LogLevel: "warn", LogFormat: "text"},
+		},
+		{
+			name: "env overrides file",
+			env: map[string]string{
+				"PAPAYA_ADDR":      ":5000",
+				"SHUTDOWN_TIMEOUT": "2s",
+				"LOG_LEVEL":        "debug",
+				"LOG_FORMAT":       "json",
+			},
+			want: Config{Addr: ":5000", ShutdownTimeout: 2 * time.Second, LogLevel: "debug", LogFormat: "json"},
+		},
+		{
+			name: "partial override",
+			env:  map[string]string{"LOG_LEVEL": "error"},
+			want: Config{Addr: "127.0.0.1:4000", ShutdownTimeout: 30 * time.Second, LogLevel: "error", LogFormat: "text"},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "papaya.yaml")
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PROFILE", ProfileProd)
+			for _, key := range []string{"PAPAYA_ADDR", "SHUTDOWN_TIMEOUT", "LOG_LEVEL", "LOG_FORMAT"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() = %v", err)
+			}
+			if *cfg != tt.want {
+				t.Errorf("Load() = %+v, want %+v", *cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	t.Setenv("PROFILE", ProfileProd)
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("addr: [unclosed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		prefix string
+	}{
+		{"missing file", filepath.Join(dir, "missing.yaml"), "read config:"},
+		{"invalid yaml", bad, "parse config " + bad},
+	}
+	for _, tt := range tests {
+		_, err := Load(tt.path)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.prefix) {
+			t.Errorf("%s: Load() = %v, want error starting with %q", tt.name, err, tt.prefix)
+		}
+	}
+}
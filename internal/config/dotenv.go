@@ -0,0 +1,80 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// loadDotenv sets variables from a KEY=VALUE file at path. Variables already
+// present in the environment are left untouched. A missing file is not an
+// error.
+func loadDotenv(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		key, val, ok, err := parseDotenvLine(sc.Text())
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		if !ok {
+			continue
+		}
+		if _, set := os.LookupEnv(key); set {
+			continue
+		}
+		if err := os.Setenv(key, val); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// parseDotenvLine parses one .env line. ok is false for blank and comment
+// lines. A value wrapped in one matching pair of single or double quotes is
+// taken verbatim; an unquoted value ends at a # preceded by whitespace.
+func parseDotenvLine(line string) (key, val string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+	line = strings.TrimPrefix(line, "export ")
+
+	key, raw, found := strings.Cut(line, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", false, errors.New("expected KEY=VALUE")
+	}
+
+	val = strings.TrimSpace(raw)
+	if val != "" && (val[0] == '"' || val[0] == '\'') {
+		end := strings.IndexByte(val[1:], val[0])
+		if end < 0 {
+			return "", "", false, errors.New("unterminated quoted value")
+		}
+		rest := strings.TrimSpace(val[end+2:])
+		if rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", "", false, errors.New("unexpected text after quoted value")
+		}
+		return key, val[1 : end+1], true, nil
+	}
+
+	// Scan the untrimmed value so "KEY= # note" is an empty value too.
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			raw = raw[:i]
+			break
+		}
+	}
+	return key, strings.TrimSpace(raw), true, nil
+}
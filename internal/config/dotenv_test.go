@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseDotenvLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		key     string
+		val     string
+		ok      bool
+		wantErr bool
+	}{
+		{line: "", ok: false},
+		{line: "   ", ok: false},
+		{line: "# comment", ok: false},
+		{line: "KEY=value", key: "KEY", val: "value", ok: true},
+		{line: "  KEY = value  ", key: "KEY", val: "value", ok: true},
+		{line: "KEY=", key: "KEY", val: "", ok: true},
+		{line: "export KEY=value", key: "KEY", val: "value", ok: true},
+		{line: "KEY=value # comment", key: "KEY", val: "value", ok: true},
+		{line: "PAPAYA_ADDR=:5000\t# port", key: "PAPAYA_ADDR", val: ":5000", ok: true},
+		{line: "KEY=value\t\t#comment", key: "KEY", val: "value", ok: true},
+		{line: "KEY=\t# only a comment", key: "KEY", val: "", ok: true},
+		{line: "KEY=a#b", key: "KEY", val: "a#b", ok: true},
+		{line: "KEY=a=b", key: "KEY", val: "a=b", ok: true},
+		{line: `KEY="quoted value"`, key: "KEY", val: "quoted value", ok: true},
+		{line: `KEY='single'`, key: "KEY", val: "single", ok: true},
+		{line: `KEY="a # b" # comment`, key: "KEY", val: "a # b", ok: true},
+		{line: `KEY="it's"`, key: "KEY", val: "it's", ok: true},
+		{line: `TOKEN=abc"`, key: "TOKEN", val: `abc"`, ok: true},
+		{line: `TOKEN=abc'`, key: "TOKEN", val: `abc'`, ok: true},
+		{line: `TOKEN='x"`, wantErr: true},
+		{line: `TOKEN="x" y`, wantErr: true},
+		{line: "no equals sign", wantErr: true},
+		{line: "=value", wantErr: true},
+	}
+	for _, tt := range tests {
+		key, val, ok, err := parseDotenvLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDotenvLine(%q) err = %v, wantErr %v", tt.line, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if key != tt.key || val != tt.val || ok != tt.ok {
+			t.Errorf("parseDotenvLine(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.line, key, val, ok, tt.key, tt.val, tt.ok)
+		}
+	}
+}
+
+func TestLoadDotenv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	data := "# settings\nPAPAYA_TEST_NEW=from-file\nPAPAYA_TEST_SET=from-file\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PAPAYA_TEST_SET", "from-env")
+	t.Cleanup(func() { os.Unsetenv("PAPAYA_TEST_NEW") })
+
+	if err := loadDotenv(path); err != nil {
+		t.Fatalf("loadDotenv() = %v", err)
+	}
+	if got := os.Getenv("PAPAYA_TEST_NEW"); got != "from-file" {
+		t.Errorf("PAPAYA_TEST_NEW = %q, want from-file", got)
+	}
+	if got := os.Getenv("PAPAYA_TEST_SET"); got != "from-env" {
+		t.Errorf("PAPAYA_TEST_SET = %q, want existing environment to win", got)
+	}
+}
+
+func TestLoadDotenvMissingFile(t *testing.T) {
+	if err := loadDotenv(filepath.Join(t.TempDir(), ".env")); err != nil {
+		t.Fatalf("loadDotenv() = %v, want nil for missing file", err)
+	}
+}
+
+func TestLoadDotenvMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("PAPAYA_TEST_OK=1\nbroken\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Unsetenv("PAPAYA_TEST_OK") })
+
+	err := loadDotenv(path)
+	if err == nil {
+		t.Fatal("loadDotenv() = nil, want error for malformed line")
+	}
+	if want := path + ":2: expected KEY=VALUE"; err.Error() != want {
+		t.Errorf("loadDotenv() = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ValidationError aggregates every problem found in a Config.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks cfg and reports all problems at once.
+func (cfg *Config) Validate() error {
+	var problems []string
+
+	if cfg.Addr == "" {
+		problems = append(problems, "addr: must not be empty")
+	} else if _, port, err := net.SplitHostPort(cfg.Addr); err != nil {
+		problems = append(problems, fmt.Sprintf("addr: %q is not host:port", cfg.Addr))
+	} else if _, err := net.LookupPort("tcp", port); err != nil {
+		problems = append(problems, fmt.Sprintf("addr: invalid port %q", port))
+	}
+
+	if cfg.ShutdownTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("shutdown_timeout: %s must be positive", cfg.ShutdownTimeout))
+	}
+
+	switch strings.ToLower(cfg.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("log_level: %q is not one of debug, info, warn, error", cfg.LogLevel))
+	}
+
+	switch strings.ToLower(cfg.LogFormat) {
+	case "text", "json":
+	default:
+		problems = append(problems, fmt.Sprintf("log_format: %q is not one of text, json", cfg.LogFormat))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
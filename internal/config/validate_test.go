@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() Config {
+	return Config{
+		Addr:            ":3000",
+		ShutdownTimeout: 10 * time.Second,
+		LogLevel:        "info",
+		LogFormat:       "json",
+	}
+}
+
+func TestValidateAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		ok   bool
+	}{
+		{":3000", true},
+		{"localhost:3000", true},
+		{":http", true},
+		{":0", true},
+		{"", false},
+		{"3000", false},
+		{":99999", false},
+		{":no-such-service", false},
+	}
+	for _, tt := range tests {
+		cfg := validConfig()
+		cfg.Addr = tt.addr
+		if err := cfg.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate() with addr %q = %v, want ok=%v", tt.addr, err, tt.ok)
+		}
+	}
+}
+
+func TestValidateAggregatesProblems(t *testing.T) {
+	cfg := Config{
+		Addr:            "foo",
+		ShutdownTimeout: -time.Second,
+		LogLevel:        "loud",
+		LogFormat:       "xml",
+	}
+
+	err := cfg.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() = %v, want *ValidationError", err)
+	}
+
+	want := []string{"addr:", "shutdown_timeout:", "log_level:", "log_format:"}
+	if len(verr.Problems) != len(want) {
+		t.Fatalf("got %d problems %q, want %d", len(verr.Problems), verr.Problems, len(want))
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(verr.Problems[i], prefix) {
+			t.Errorf("problem %d = %q, want prefix %q", i, verr.Problems[i], prefix)
+		}
+	}
+}
+
+func TestValidateOK(t *testing.T) {
+	cfg := validConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
@@ -1,16 +1,82 @@
 package main
 
 import (
-	"log"
-	"net/http"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
 
-	"github.com/guanke/papaya/api"
+	"github.com/guanke/papaya/internal/config"
+	"github.com/guanke/papaya/internal/logger"
 )
 
+type command struct {
+	Name  string
+	Short string
+	Run   func(configPath string, args []string) error
+}
+
+var commands = []command{
+	{Name: "serve", Short: "run the HTTP server (default)", Run: serve},
+	{Name: "doctor", Short: "check configuration and dependencies", Run: doctor},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: papaya [command] [-config path] [args]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.Name, c.Short)
+	}
+	fmt.Fprintf(os.Stderr, "  %-10s %s\n", "help", "list commands")
+}
+
 func main() {
-	s := http.NewServeMux()
+	args := os.Args[1:]
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		usage()
+		return
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].Name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("papaya "+name, flag.ExitOnError)
+	configPath := fs.String("config", "", "path to papaya.yaml")
+	fs.Usage = func() {
+		usage()
+		fmt.Fprintf(os.Stderr, "\nflags for %s:\n", name)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if err := cmd.Run(*configPath, fs.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "papaya %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
 
-	s.HandleFunc("/healthcheck", api.Healthcheck)
-	addr := ":3000"
-	log.Fatal(http.ListenAndServe(addr, s))
+// loadConfig loads the config at path and installs the logger it
+// configures.
+func loadConfig(path string) (*config.Config, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := logger.Init(cfg.LogLevel, cfg.LogFormat); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }